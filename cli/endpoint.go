@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithy "github.com/aws/smithy-go"
+)
+
+// EndpointConfig lets icebreaker target S3-compatible gateways (MinIO, Ceph,
+// Wasabi, Arvados Keep, ...) instead of AWS S3 directly.
+type EndpointConfig struct {
+	Endpoint      string
+	Region        string
+	Profile       string
+	AccessKeyFile string
+	SecretKeyFile string
+	PathStyle     bool
+}
+
+// buildAWSConfig resolves an aws.Config from the standard credential chain,
+// optionally overridden by -profile and the -access-key-file/-secret-key-file
+// pair.
+func buildAWSConfig(ctx context.Context, ec EndpointConfig) (aws.Config, error) {
+	var opts []func(*config.LoadOptions) error
+
+	if ec.Profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(ec.Profile))
+	}
+
+	if ec.Region != "" {
+		opts = append(opts, config.WithRegion(ec.Region))
+	}
+
+	if ec.AccessKeyFile != "" || ec.SecretKeyFile != "" {
+		if ec.AccessKeyFile == "" || ec.SecretKeyFile == "" {
+			return aws.Config{}, errors.New("-access-key-file and -secret-key-file must be set together")
+		}
+
+		accessKey, err := readCredentialFile(ec.AccessKeyFile)
+		if err != nil {
+			return aws.Config{}, fmt.Errorf("reading -access-key-file: %w", err)
+		}
+
+		secretKey, err := readCredentialFile(ec.SecretKeyFile)
+		if err != nil {
+			return aws.Config{}, fmt.Errorf("reading -secret-key-file: %w", err)
+		}
+
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
+		))
+	}
+
+	return config.LoadDefaultConfig(ctx, opts...)
+}
+
+// readCredentialFile reads a single credential value from path, trimming
+// surrounding whitespace/newlines the way keepstore-style credential files
+// are typically written.
+func readCredentialFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+
+	return value, nil
+}
+
+// newS3Client builds an S3 client from cfg, pointed at ec.Endpoint (if set)
+// and using path-style addressing when ec.PathStyle is set.
+func newS3Client(cfg aws.Config, ec EndpointConfig) *s3.Client {
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if ec.Endpoint != "" {
+			o.BaseEndpoint = aws.String(ec.Endpoint)
+		}
+
+		o.UsePathStyle = ec.PathStyle
+	})
+}
+
+// compatProbeKey is a key that should never exist, used to exercise
+// RestoreObject without touching real data.
+const compatProbeKey = ".icebreaker-restore-compat-probe"
+
+// unsupportedOperationErrorCodes are the error codes S3-compatible gateways
+// tend to return for an API they haven't implemented, as opposed to errors
+// that prove the operation is understood (e.g. the key not existing).
+var unsupportedOperationErrorCodes = map[string]bool{
+	"NotImplemented":          true,
+	"NotImplementedException": true,
+	"UnsupportedOperation":    true,
+	"MethodNotAllowed":        true,
+}
+
+// probeRestoreSupport exercises RestoreObject against bucket with a key that
+// shouldn't exist. An error proving the server understood the request (e.g.
+// NoSuchKey) is treated as support confirmed; an error indicating the
+// operation itself isn't implemented is surfaced as a clear, actionable
+// error instead of letting every subsequent restore fail individually.
+func probeRestoreSupport(ctx context.Context, s3Client *s3.Client, bucket string) error {
+	_, err := s3Client.RestoreObject(ctx, &s3.RestoreObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(compatProbeKey),
+		RestoreRequest: &types.RestoreRequest{
+			Days: aws.Int32(1),
+			GlacierJobParameters: &types.GlacierJobParameters{
+				Tier: types.TierBulk,
+			},
+		},
+	})
+	if err == nil {
+		return nil
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && unsupportedOperationErrorCodes[apiErr.ErrorCode()] {
+		return fmt.Errorf("endpoint does not appear to support RestoreObject (got %s): %w", apiErr.ErrorCode(), err)
+	}
+
+	return nil
+}
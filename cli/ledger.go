@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// RecordStatus is the last-seen restore state recorded in the ledger.
+type RecordStatus string
+
+const (
+	StatusRequested  RecordStatus = "requested"
+	StatusInProgress RecordStatus = "in_progress"
+	StatusRestored   RecordStatus = "restored"
+)
+
+const ledgerBucketName = "objects"
+
+// LedgerRecord is the persisted state for one bucket/key.
+type LedgerRecord struct {
+	Status      RecordStatus `json:"status"`
+	RequestedAt *time.Time   `json:"requestedAt,omitempty"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+// Ledger persists restore progress across runs in a local BoltDB file, so a
+// long restoration job can be interrupted and resumed with -state.
+type Ledger struct {
+	db *bbolt.DB
+}
+
+// OpenLedger opens (creating if necessary) the BoltDB ledger at path.
+func OpenLedger(path string) (*Ledger, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening ledger: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(ledgerBucketName))
+
+		return err
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("initializing ledger: %w", err)
+	}
+
+	return &Ledger{db: db}, nil
+}
+
+// Close releases the ledger's file lock.
+func (l *Ledger) Close() error {
+	return l.db.Close()
+}
+
+func ledgerKey(bucket, key string) []byte {
+	return []byte(bucket + "/" + key)
+}
+
+// Get returns the record for bucket/key, or nil if it isn't tracked yet.
+func (l *Ledger) Get(bucket, key string) (*LedgerRecord, error) {
+	var record *LedgerRecord
+
+	err := l.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket([]byte(ledgerBucketName)).Get(ledgerKey(bucket, key))
+		if data == nil {
+			return nil
+		}
+
+		var rec LedgerRecord
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return err
+		}
+
+		record = &rec
+
+		return nil
+	})
+
+	return record, err
+}
+
+// Put records the current status for bucket/key.
+func (l *Ledger) Put(bucket, key string, record LedgerRecord) error {
+	record.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return l.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(ledgerBucketName)).Put(ledgerKey(bucket, key), data)
+	})
+}
+
+// StatusCounts tallies records by status, for the -report mode.
+func (l *Ledger) StatusCounts() (map[RecordStatus]int, error) {
+	counts := make(map[RecordStatus]int)
+
+	err := l.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(ledgerBucketName)).ForEach(func(_, v []byte) error {
+			var rec LedgerRecord
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+
+			counts[rec.Status]++
+
+			return nil
+		})
+	})
+
+	return counts, err
+}
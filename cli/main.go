@@ -3,17 +3,22 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
+	"syscall"
 	"text/template"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	charm_log "github.com/charmbracelet/log"
+	"golang.org/x/time/rate"
 )
 
 var logger = charm_log.NewWithOptions(os.Stdout, charm_log.Options{
@@ -34,6 +39,12 @@ Options:
 Examples:
   {{.ProgramName}} -path s3://mybucket/myfolder
   {{.ProgramName}} -path s3://mybucket/myfolder -days 7 -dry-run
+  {{.ProgramName}} -path s3://mybucket/myfolder -workers 20 -rps 15
+  {{.ProgramName}} -path s3://mybucket/myfolder -include-prefix "logs/*" -tag restore=true
+  {{.ProgramName}} -path s3://mybucket/myfolder -tier Expedited -dry-run
+  {{.ProgramName}} -path s3://mybucket/myfolder -state ./restore.db -days 7
+  {{.ProgramName}} -report -state ./restore.db
+  {{.ProgramName}} -path s3://mybucket/myfolder -endpoint https://minio.local:9000 -path-style -profile minio
 `
 
 func appUsage() {
@@ -63,21 +74,102 @@ func appUsage() {
 	}
 }
 
+// stringSliceFlag collects repeated occurrences of a flag into a slice, e.g.
+// -include-prefix "a/*" -include-prefix "b/*".
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
+
+// SelectionFilters narrows which objects processObject acts on, beyond the
+// Deep Archive storage class check.
+type SelectionFilters struct {
+	IncludePrefixes []*regexp.Regexp
+	ExcludePrefixes []*regexp.Regexp
+	Tags            map[string]string
+	ExcludeTags     map[string]string
+}
+
+func (f SelectionFilters) needsObjectTags() bool {
+	return len(f.Tags) > 0 || len(f.ExcludeTags) > 0
+}
+
+// tierByName maps the -tier flag value to the SDK's restore tier constant.
+var tierByName = map[string]types.Tier{
+	"Bulk":      types.TierBulk,
+	"Standard":  types.TierStandard,
+	"Expedited": types.TierExpedited,
+}
+
 type AppConfig struct {
-	s3Path string
-	days   int
-	dryRun bool
+	s3Path      string
+	days        int
+	dryRun      bool
+	workers     int
+	rps         float64
+	filters     SelectionFilters
+	tier        types.Tier
+	region      string
+	pricingFile string
+	statePath   string
+	force       bool
+	report      bool
+	action      PostRestoreAction
+	endpoint    EndpointConfig
 }
 
 func parseFlags() AppConfig {
 	s3Path := flag.String("path", "", "The S3 path to restore (e.g. s3://mybucket/myfolder)")
 	days := flag.Int("days", 1, "Number of days to restore objects for")
 	dryRun := flag.Bool("dry-run", false, "List affected objects without restoring")
+	workers := flag.Int("workers", 10, "Number of concurrent restore workers")
+	rps := flag.Float64("rps", 10, "Maximum S3 requests per second (covers both restore requests and -tag/-exclude-tag lookups)")
+	tier := flag.String("tier", "Bulk", "Restore tier: Bulk, Standard, or Expedited")
+	region := flag.String("region", "", "Region to use for cost estimation (defaults to the SDK's resolved region)")
+	pricingFile := flag.String("pricing-file", "", "JSON file overriding the built-in per-tier, per-region pricing table")
+	statePath := flag.String("state", "", "Path to a BoltDB ledger tracking restore progress, for resuming interrupted runs")
+	force := flag.Bool("force", false, "Re-request restoration even for objects the ledger already marks as requested/restored")
+	report := flag.Bool("report", false, "Print status counts from the -state ledger and exit, without contacting S3")
+	action := flag.String("action", "", "Post-restore action to run once an object is readable: download or copy")
+	dest := flag.String("dest", "", "Destination for -action: a local directory for download, or an s3://bucket/prefix for copy")
+	destStorageClass := flag.String("storage-class", "STANDARD_IA", "Destination storage class for -action copy")
+	pollInterval := flag.Duration("poll-interval", 30*time.Second, "Initial interval between restore-readiness checks for -action (backs off exponentially)")
+	pollTimeout := flag.Duration("poll-timeout", 48*time.Hour, "How long to keep polling for -action before giving up on an object")
+	endpoint := flag.String("endpoint", "", "Custom S3-compatible endpoint URL (e.g. for MinIO, Ceph, Wasabi, Arvados Keep)")
+	profile := flag.String("profile", "", "Shared AWS config/credentials profile to use")
+	accessKeyFile := flag.String("access-key-file", "", "File containing the access key, for endpoints without an IAM-style credential chain")
+	secretKeyFile := flag.String("secret-key-file", "", "File containing the secret key, for endpoints without an IAM-style credential chain")
+	pathStyle := flag.Bool("path-style", false, "Use path-style addressing (bucket.in.path instead of bucket.in.host), required by most S3-compatible gateways")
+
+	var includePrefixes, excludePrefixes stringSliceFlag
+	flag.Var(&includePrefixes, "include-prefix", "Glob-style key pattern to restore (repeatable); if set, only matching keys are considered")
+	flag.Var(&excludePrefixes, "exclude-prefix", "Glob-style key pattern to skip (repeatable); takes precedence over -include-prefix")
+
+	var tags, excludeTags stringSliceFlag
+	flag.Var(&tags, "tag", "Only restore objects carrying this tag, as key=value (repeatable)")
+	flag.Var(&excludeTags, "exclude-tag", "Skip objects carrying this tag, as key=value (repeatable); takes precedence over -tag")
 
 	flag.Usage = appUsage
 
 	flag.Parse()
 
+	if *report {
+		if *statePath == "" {
+			logger.Error("Error: -report requires -state <path>")
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		return AppConfig{report: true, statePath: *statePath}
+	}
+
 	if *s3Path == "" {
 		logger.Error("Error: -path is required")
 		flag.Usage()
@@ -90,30 +182,211 @@ func parseFlags() AppConfig {
 		os.Exit(1)
 	}
 
+	if *workers < 1 {
+		logger.Error("Error: -workers must be at least 1")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *rps <= 0 {
+		logger.Error("Error: -rps must be greater than 0")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	tagMap, err := parseTagFlags(tags)
+	if err != nil {
+		logger.Errorf("Error: -tag %v", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	excludeTagMap, err := parseTagFlags(excludeTags)
+	if err != nil {
+		logger.Errorf("Error: -exclude-tag %v", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	includePrefixGlobs, err := compileGlobs(includePrefixes)
+	if err != nil {
+		logger.Errorf("Error: -include-prefix %v", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	excludePrefixGlobs, err := compileGlobs(excludePrefixes)
+	if err != nil {
+		logger.Errorf("Error: -exclude-prefix %v", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	restoreTier, ok := tierByName[*tier]
+	if !ok {
+		logger.Errorf("Error: -tier must be one of Bulk, Standard, Expedited, got %q", *tier)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	postRestoreAction := PostRestoreAction{
+		Mode:         *action,
+		Dest:         *dest,
+		StorageClass: types.StorageClass(*destStorageClass),
+		PollInterval: *pollInterval,
+		PollTimeout:  *pollTimeout,
+	}
+
+	switch postRestoreAction.Mode {
+	case "":
+		// No post-restore action configured.
+	case actionDownload:
+		if postRestoreAction.Dest == "" {
+			logger.Error("Error: -action download requires -dest <local path>")
+			flag.Usage()
+			os.Exit(1)
+		}
+	case actionCopy:
+		if !strings.HasPrefix(postRestoreAction.Dest, "s3://") {
+			logger.Error("Error: -action copy requires -dest s3://bucket/prefix")
+			flag.Usage()
+			os.Exit(1)
+		}
+	default:
+		logger.Errorf("Error: -action must be %q or %q, got %q", actionDownload, actionCopy, postRestoreAction.Mode)
+		flag.Usage()
+		os.Exit(1)
+	}
+
 	return AppConfig{
-		s3Path: *s3Path,
-		days:   *days,
-		dryRun: *dryRun,
+		s3Path:  *s3Path,
+		days:    *days,
+		dryRun:  *dryRun,
+		workers: *workers,
+		rps:     *rps,
+		filters: SelectionFilters{
+			IncludePrefixes: includePrefixGlobs,
+			ExcludePrefixes: excludePrefixGlobs,
+			Tags:            tagMap,
+			ExcludeTags:     excludeTagMap,
+		},
+		tier:        restoreTier,
+		region:      *region,
+		pricingFile: *pricingFile,
+		statePath:   *statePath,
+		force:       *force,
+		action:      postRestoreAction,
+		endpoint: EndpointConfig{
+			Endpoint:      *endpoint,
+			Region:        *region,
+			Profile:       *profile,
+			AccessKeyFile: *accessKeyFile,
+			SecretKeyFile: *secretKeyFile,
+			PathStyle:     *pathStyle,
+		},
+	}
+}
+
+// parseTagFlags turns repeated key=value strings into a map, as produced by
+// the -tag and -exclude-tag flags.
+func parseTagFlags(raw []string) (map[string]string, error) {
+	tags := make(map[string]string, len(raw))
+
+	for _, kv := range raw {
+		key, value, found := strings.Cut(kv, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid tag %q, expected key=value", kv)
+		}
+
+		tags[key] = value
 	}
+
+	return tags, nil
 }
 
 func main() {
 	appCfg := parseFlags()
 
-	pathParts := strings.SplitN(strings.TrimPrefix(appCfg.s3Path, "s3://"), "/", 2)
-	bucket := pathParts[0]
+	if appCfg.report {
+		printLedgerReport(appCfg.statePath)
+
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	prefix := ""
-	if len(pathParts) > 1 {
-		prefix = pathParts[1]
+	bucket, prefix, err := parseS3Path(appCfg.s3Path)
+	if err != nil {
+		logger.Fatalf("Invalid -path: %v", err)
 	}
 
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := buildAWSConfig(ctx, appCfg.endpoint)
 	if err != nil {
 		logger.Fatalf("Unable to load SDK config, %v", err)
 	}
 
-	s3Client := s3.NewFromConfig(cfg)
+	s3Client := newS3Client(cfg, appCfg.endpoint)
+
+	if !appCfg.dryRun && appCfg.endpoint.Endpoint != "" {
+		if err := probeRestoreSupport(ctx, s3Client, bucket); err != nil {
+			logger.Fatalf("%v", err)
+		}
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(appCfg.rps), 1)
+
+	region := appCfg.region
+	if region == "" {
+		region = cfg.Region
+	}
+
+	var report *DryRunReport
+	if appCfg.dryRun {
+		report = NewDryRunReport(appCfg.tier, region)
+	}
+
+	var ledger *Ledger
+	if appCfg.statePath != "" {
+		ledger, err = OpenLedger(appCfg.statePath)
+		if err != nil {
+			logger.Fatalf("Failed to open ledger: %v", err)
+		}
+
+		defer ledger.Close()
+	}
+
+	var actionWG sync.WaitGroup
+
+	opts := ProcessOptions{
+		S3Client: s3Client,
+		Bucket:   bucket,
+		Days:     appCfg.days,
+		DryRun:   appCfg.dryRun,
+		Tier:     appCfg.tier,
+		Limiter:  limiter,
+		Filters:  appCfg.filters,
+		Report:   report,
+		Ledger:   ledger,
+		Force:    appCfg.force,
+		Action:   appCfg.action,
+		ActionWG: &actionWG,
+	}
+
+	objects := make(chan types.Object)
+
+	var wg sync.WaitGroup
+	for i := 0; i < appCfg.workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for obj := range objects {
+				processObject(ctx, obj, opts)
+			}
+		}()
+	}
 
 	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
 		Bucket: aws.String(bucket),
@@ -123,67 +396,337 @@ func main() {
 		},
 	})
 
-	logger.Infof("Processing objects in s3://%s/%s", bucket, prefix)
+	logger.Infof("Processing objects in s3://%s/%s with %d workers at %.1f req/s", bucket, prefix, appCfg.workers, appCfg.rps)
 
+dispatch:
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(context.TODO())
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			logger.Fatalf("Failed to get page, %v", err)
+			logger.Errorf("Failed to get page, %v", err)
+
+			break
 		}
 
 		for _, obj := range page.Contents {
-			processObject(obj, s3Client, bucket, appCfg.days, appCfg.dryRun)
+			select {
+			case objects <- obj:
+			case <-ctx.Done():
+				logger.Warn("Shutdown requested, waiting for in-flight restores to finish...")
+
+				break dispatch
+			}
+		}
+	}
+
+	close(objects)
+	wg.Wait()
+
+	if appCfg.action.enabled() {
+		logger.Info("Waiting for post-restore actions to complete...")
+		actionWG.Wait()
+	}
+
+	if report != nil {
+		var table map[string]map[string]TierPricing
+
+		switch {
+		case appCfg.pricingFile != "":
+			t, err := loadPricingTable(appCfg.pricingFile)
+			if err != nil {
+				logger.Warnf("Failed to load pricing file, falling back to built-in rates: %v", err)
+				t = defaultPricingTable
+			}
+
+			table = t
+		case appCfg.endpoint.Endpoint != "":
+			logger.Warn("Skipping cost estimate: built-in pricing is AWS-specific and doesn't apply to a custom endpoint; pass -pricing-file for this provider's rates")
+		default:
+			table = defaultPricingTable
 		}
+
+		report.Finalize(table)
+		report.Print()
 	}
 
 	logger.Info("Processing complete.")
 }
 
-func processObject(obj types.Object, s3Client *s3.Client, bucket string, days int, dryRun bool) {
+// printLedgerReport reads status counts from the ledger at path and prints
+// them, without contacting S3.
+func printLedgerReport(statePath string) {
+	ledger, err := OpenLedger(statePath)
+	if err != nil {
+		logger.Fatalf("Failed to open ledger: %v", err)
+	}
+	defer ledger.Close()
+
+	counts, err := ledger.StatusCounts()
+	if err != nil {
+		logger.Fatalf("Failed to read ledger: %v", err)
+	}
+
+	logger.Info("Ledger status counts:")
+	logger.Infof("  %s: %d", StatusRequested, counts[StatusRequested])
+	logger.Infof("  %s: %d", StatusInProgress, counts[StatusInProgress])
+	logger.Infof("  %s: %d", StatusRestored, counts[StatusRestored])
+}
+
+// ProcessOptions bundles the dependencies and run-time configuration that
+// processObject needs, so adding a new switch doesn't keep growing its
+// parameter list.
+type ProcessOptions struct {
+	S3Client *s3.Client
+	Bucket   string
+	Days     int
+	DryRun   bool
+	Tier     types.Tier
+	Limiter  *rate.Limiter
+	Filters  SelectionFilters
+	Report   *DryRunReport
+	Ledger   *Ledger
+	Force    bool
+	Action   PostRestoreAction
+	ActionWG *sync.WaitGroup
+}
+
+func processObject(ctx context.Context, obj types.Object, opts ProcessOptions) {
 	if obj.Key == nil {
 		return
 	}
 
 	objectKey := *obj.Key
 
+	if opts.Report != nil && obj.Size != nil {
+		opts.Report.ObserveSeen(obj.StorageClass, *obj.Size)
+	}
+
 	if obj.StorageClass != types.ObjectStorageClass(types.StorageClassDeepArchive) {
 		return
 	}
 
-	if dryRun {
+	if !matchesPrefixFilters(objectKey, opts.Filters) {
+		return
+	}
+
+	if opts.Filters.needsObjectTags() {
+		if err := opts.Limiter.Wait(ctx); err != nil {
+			return
+		}
+
+		matches, err := matchesTagFilters(ctx, opts.S3Client, opts.Bucket, objectKey, opts.Filters)
+		if err != nil {
+			logger.Warnf("Failed to get tags for %s: %v", objectKey, err)
+
+			return
+		}
+
+		if !matches {
+			return
+		}
+	}
+
+	if opts.DryRun {
+		if opts.Report != nil && obj.Size != nil {
+			opts.Report.ObserveEligible(*obj.Size)
+		}
+
 		logger.Infof("🔍 Would restore: %s", objectKey)
 
 		return
 	}
 
+	skipRequest := opts.Ledger != nil && !opts.Force && ledgerSaysSkip(opts.Ledger, opts.Bucket, objectKey)
+
 	restoreStatus := obj.RestoreStatus
 
 	if objectNotBeingRestored(restoreStatus) {
-		logger.Infof("🚀 Requesting restoration: %s", objectKey)
+		if skipRequest {
+			logger.Infof("⏭️  Skipping restore request for %s: ledger marks it as already requested or restored", objectKey)
+
+			return
+		}
 
-		_, err := s3Client.RestoreObject(context.TODO(), &s3.RestoreObjectInput{
-			Bucket: aws.String(bucket),
+		if err := opts.Limiter.Wait(ctx); err != nil {
+			return
+		}
+
+		logger.Infof("🚀 Requesting restoration (%s tier): %s", opts.Tier, objectKey)
+
+		// Use a detached context so a shutdown signal doesn't abort a restore
+		// request that has already been admitted by the rate limiter.
+		_, err := opts.S3Client.RestoreObject(context.Background(), &s3.RestoreObjectInput{
+			Bucket: aws.String(opts.Bucket),
 			Key:    aws.String(objectKey),
 			RestoreRequest: &types.RestoreRequest{
-				Days: aws.Int32(int32(days)),
+				Days: aws.Int32(int32(opts.Days)),
 				GlacierJobParameters: &types.GlacierJobParameters{
-					Tier: types.TierBulk,
+					Tier: opts.Tier,
 				},
 			},
 		})
 		if err != nil {
 			logger.Warnf("Failed to restore %s: %v", objectKey, err)
+
+			return
 		}
+
+		putLedgerRecord(opts.Ledger, opts.Bucket, objectKey, LedgerRecord{Status: StatusRequested, RequestedAt: timePtr(time.Now())})
+		schedulePostRestoreAction(ctx, opts.S3Client, opts.Bucket, objectKey, opts.Action, opts.ActionWG)
 	} else if objectIsRestored(restoreStatus) {
 		expiryDate := "N/A"
+
+		var expiresAt *time.Time
+
 		if restoreStatus.RestoreExpiryDate != nil {
 			expiryDate = restoreStatus.RestoreExpiryDate.Format(time.RFC3339)
+			expiresAt = restoreStatus.RestoreExpiryDate
 		}
 
 		logger.Infof("✅ Restored: %s, ⌛ until: %s", objectKey, expiryDate)
+
+		putLedgerRecord(opts.Ledger, opts.Bucket, objectKey, LedgerRecord{Status: StatusRestored, ExpiresAt: expiresAt})
+		schedulePostRestoreAction(ctx, opts.S3Client, opts.Bucket, objectKey, opts.Action, opts.ActionWG)
 	} else if restoreStatus != nil && restoreStatus.IsRestoreInProgress != nil && *restoreStatus.IsRestoreInProgress {
 		logger.Infof("🏗️ Restoring: %s", objectKey)
+
+		putLedgerRecord(opts.Ledger, opts.Bucket, objectKey, LedgerRecord{Status: StatusInProgress})
+		schedulePostRestoreAction(ctx, opts.S3Client, opts.Bucket, objectKey, opts.Action, opts.ActionWG)
+	}
+}
+
+// ledgerSaysSkip reports whether the ledger already considers bucket/key
+// requested/in-progress, or restored and not yet expired.
+func ledgerSaysSkip(ledger *Ledger, bucket, key string) bool {
+	record, err := ledger.Get(bucket, key)
+	if err != nil {
+		logger.Warnf("Failed to read ledger for %s: %v", key, err)
+
+		return false
+	}
+
+	if record == nil {
+		return false
 	}
+
+	switch record.Status {
+	case StatusRequested, StatusInProgress:
+		return true
+	case StatusRestored:
+		return record.ExpiresAt == nil || record.ExpiresAt.After(time.Now())
+	default:
+		return false
+	}
+}
+
+// putLedgerRecord writes record if a ledger is configured, logging (but not
+// failing the run) on error.
+func putLedgerRecord(ledger *Ledger, bucket, key string, record LedgerRecord) {
+	if ledger == nil {
+		return
+	}
+
+	if err := ledger.Put(bucket, key, record); err != nil {
+		logger.Warnf("Failed to update ledger for %s: %v", key, err)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// matchesPrefixFilters reports whether key passes the configured include and
+// exclude glob patterns. Exclude patterns always win. An empty include list
+// matches everything.
+func matchesPrefixFilters(key string, filters SelectionFilters) bool {
+	for _, pattern := range filters.ExcludePrefixes {
+		if pattern.MatchString(key) {
+			return false
+		}
+	}
+
+	if len(filters.IncludePrefixes) == 0 {
+		return true
+	}
+
+	for _, pattern := range filters.IncludePrefixes {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileGlobs translates shell-style glob patterns (where "*" matches any
+// sequence of characters, including "/", and "?" matches any single
+// character) into compiled regexps. "*" is deliberately allowed to cross
+// path segment boundaries, so a pattern like "logs/*" matches every key
+// under "logs/", not just its immediate children.
+func compileGlobs(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		var regex strings.Builder
+
+		regex.WriteString("^")
+
+		for _, r := range pattern {
+			switch r {
+			case '*':
+				regex.WriteString(".*")
+			case '?':
+				regex.WriteString(".")
+			default:
+				regex.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+
+		regex.WriteString("$")
+
+		re, err := regexp.Compile(regex.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// matchesTagFilters fetches the object's tags and checks them against the
+// configured -tag and -exclude-tag predicates. Exclude predicates always win.
+func matchesTagFilters(ctx context.Context, s3Client *s3.Client, bucket, key string, filters SelectionFilters) (bool, error) {
+	out, err := s3Client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	objectTags := make(map[string]string, len(out.TagSet))
+	for _, tag := range out.TagSet {
+		if tag.Key == nil || tag.Value == nil {
+			continue
+		}
+
+		objectTags[*tag.Key] = *tag.Value
+	}
+
+	for k, v := range filters.ExcludeTags {
+		if objectTags[k] == v {
+			return false, nil
+		}
+	}
+
+	for k, v := range filters.Tags {
+		if objectTags[k] != v {
+			return false, nil
+		}
+	}
+
+	return true, nil
 }
 
 func objectNotBeingRestored(status *types.RestoreStatus) bool {
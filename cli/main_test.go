@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+func TestCompileGlobs(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"exact match", "logs/file.csv", "logs/file.csv", true},
+		{"single star matches nested segments", "logs/*", "logs/2024/01/file.csv", true},
+		{"single star matches immediate child", "logs/*", "logs/file.csv", true},
+		{"single star requires prefix", "logs/*", "archive/file.csv", false},
+		{"question mark matches one rune", "logs/file?.csv", "logs/file1.csv", true},
+		{"question mark does not match multiple runes", "logs/file?.csv", "logs/file12.csv", false},
+		{"special regex characters are literal", "logs/file(1).csv", "logs/file(1).csv", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, err := compileGlobs([]string{tt.pattern})
+			if err != nil {
+				t.Fatalf("compileGlobs(%q): %v", tt.pattern, err)
+			}
+
+			if got := compiled[0].MatchString(tt.key); got != tt.want {
+				t.Errorf("pattern %q matching %q = %v, want %v", tt.pattern, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileGlobsInvalid(t *testing.T) {
+	if _, err := compileGlobs([]string{"["}); err == nil {
+		t.Error("expected error compiling an unbalanced pattern, got nil")
+	}
+}
+
+func TestMatchesPrefixFilters(t *testing.T) {
+	include, err := compileGlobs([]string{"logs/*"})
+	if err != nil {
+		t.Fatalf("compileGlobs: %v", err)
+	}
+
+	exclude, err := compileGlobs([]string{"logs/tmp/*"})
+	if err != nil {
+		t.Fatalf("compileGlobs: %v", err)
+	}
+
+	filters := SelectionFilters{IncludePrefixes: include, ExcludePrefixes: exclude}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"logs/2024/01/file.csv", true},
+		{"logs/tmp/2024/01/file.csv", false},
+		{"archive/file.csv", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesPrefixFilters(tt.key, filters); got != tt.want {
+			t.Errorf("matchesPrefixFilters(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesPrefixFiltersNoIncludeMatchesEverything(t *testing.T) {
+	filters := SelectionFilters{}
+
+	if !matchesPrefixFilters("anything/at/all.csv", filters) {
+		t.Error("expected a key to match when no include filters are configured")
+	}
+}
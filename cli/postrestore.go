@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	actionDownload = "download"
+	actionCopy     = "copy"
+)
+
+const maxPollBackoff = 5 * time.Minute
+
+// PostRestoreAction configures what, if anything, to do once a restored
+// object actually becomes readable.
+type PostRestoreAction struct {
+	Mode         string // "", actionDownload, or actionCopy
+	Dest         string
+	StorageClass types.StorageClass
+	PollInterval time.Duration
+	PollTimeout  time.Duration
+}
+
+func (a PostRestoreAction) enabled() bool {
+	return a.Mode != ""
+}
+
+// schedulePostRestoreAction polls key until it's readable and then runs the
+// configured action, tracked on wg so the caller can wait for it to finish.
+func schedulePostRestoreAction(ctx context.Context, s3Client *s3.Client, bucket, key string, action PostRestoreAction, wg *sync.WaitGroup) {
+	if !action.enabled() {
+		return
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		if err := waitUntilRestored(ctx, s3Client, bucket, key, action.PollInterval, action.PollTimeout); err != nil {
+			logger.Warnf("Gave up waiting to %s %s: %v", action.Mode, key, err)
+
+			return
+		}
+
+		var err error
+
+		switch action.Mode {
+		case actionDownload:
+			err = downloadObject(ctx, s3Client, bucket, key, action.Dest)
+		case actionCopy:
+			err = copyObject(ctx, s3Client, bucket, key, action.Dest, action.StorageClass)
+		}
+
+		if err != nil {
+			logger.Warnf("Failed to %s %s: %v", action.Mode, key, err)
+
+			return
+		}
+
+		logger.Infof("📦 Post-restore %s complete: %s", action.Mode, key)
+	}()
+}
+
+// waitUntilRestored polls HeadObject with exponential backoff until the
+// restore's "ongoing-request" header flips to false, meaning the object is
+// now readable from S3.
+func waitUntilRestored(ctx context.Context, s3Client *s3.Client, bucket, key string, interval, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	backoff := interval
+
+	for {
+		head, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return fmt.Errorf("checking restore status: %w", err)
+		}
+
+		if restoreIsComplete(head.Restore) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("restore did not complete within %s", timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > maxPollBackoff {
+			backoff = maxPollBackoff
+		}
+	}
+}
+
+// restoreIsComplete parses the raw `Restore` header S3 returns from
+// HeadObject, e.g. `ongoing-request="false", expiry-date="..."`.
+func restoreIsComplete(restoreHeader *string) bool {
+	if restoreHeader == nil {
+		return false
+	}
+
+	return strings.Contains(*restoreHeader, `ongoing-request="false"`)
+}
+
+// safeJoin joins dest with key the way downloadObject lays objects out on
+// disk, refusing to resolve outside dest. S3 keys are opaque strings, so a
+// key containing "../" segments (or an absolute path) must not be allowed to
+// escape dest via path traversal.
+func safeJoin(dest, key string) (string, error) {
+	destPath := filepath.Join(dest, filepath.FromSlash(key))
+
+	rel, err := filepath.Rel(dest, destPath)
+	if err != nil {
+		return "", err
+	}
+
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("key %q escapes destination directory", key)
+	}
+
+	return destPath, nil
+}
+
+// downloadObject streams key from bucket into dest, preserving it as the
+// relative file path under dest.
+func downloadObject(ctx context.Context, s3Client *s3.Client, bucket, key, dest string) error {
+	out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	destPath, err := safeJoin(dest, key)
+	if err != nil {
+		return fmt.Errorf("resolving destination path for %q: %w", key, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, out.Body)
+
+	return err
+}
+
+// copyObject server-side copies key from bucket into dest (an s3://
+// bucket/prefix URL), rehydrating it into storageClass.
+func copyObject(ctx context.Context, s3Client *s3.Client, bucket, key, dest string, storageClass types.StorageClass) error {
+	destBucket, destPrefix, err := parseS3Path(dest)
+	if err != nil {
+		return fmt.Errorf("parsing -dest: %w", err)
+	}
+
+	destKey := strings.TrimSuffix(destPrefix, "/") + "/" + filepath.ToSlash(key)
+	destKey = strings.TrimPrefix(destKey, "/")
+
+	copySource := escapeCopySource(bucket, key)
+
+	_, err = s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:       aws.String(destBucket),
+		Key:          aws.String(destKey),
+		CopySource:   aws.String(copySource),
+		StorageClass: storageClass,
+	})
+
+	return err
+}
+
+// escapeCopySource builds the bucket/key value CopySource expects: each path
+// segment percent-escaped individually, with the "/" separators left literal
+// (url.PathEscape on the joined string would also escape the separators,
+// producing a CopySource that doesn't point at the object it means to copy).
+func escapeCopySource(bucket, key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return url.PathEscape(bucket) + "/" + strings.Join(segments, "/")
+}
+
+// parseS3Path splits an s3://bucket/prefix URL into its bucket and prefix.
+func parseS3Path(s3Path string) (bucket, prefix string, err error) {
+	if !strings.HasPrefix(s3Path, "s3://") {
+		return "", "", fmt.Errorf("%q must start with s3://", s3Path)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(s3Path, "s3://"), "/", 2)
+	bucket = parts[0]
+
+	if bucket == "" {
+		return "", "", fmt.Errorf("%q is missing a bucket name", s3Path)
+	}
+
+	if len(parts) > 1 {
+		prefix = parts[1]
+	}
+
+	return bucket, prefix, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+func TestEscapeCopySource(t *testing.T) {
+	tests := []struct {
+		name   string
+		bucket string
+		key    string
+		want   string
+	}{
+		{"simple key", "bucket", "file.txt", "bucket/file.txt"},
+		{"nested key preserves separators", "bucket", "folder/file.txt", "bucket/folder/file.txt"},
+		{"segment with space is escaped", "bucket", "folder/my file.txt", "bucket/folder/my%20file.txt"},
+		{"segment with percent is escaped", "bucket", "100%done.txt", "bucket/100%25done.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeCopySource(tt.bucket, tt.key); got != tt.want {
+				t.Errorf("escapeCopySource(%q, %q) = %q, want %q", tt.bucket, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	tests := []struct {
+		name    string
+		dest    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{"simple key", "/tmp/dest", "file.txt", "/tmp/dest/file.txt", false},
+		{"nested key", "/tmp/dest", "folder/file.txt", "/tmp/dest/folder/file.txt", false},
+		{"traversal escapes dest", "/tmp/dest", "../../etc/passwd", "", true},
+		{"traversal within a deeper segment", "/tmp/dest", "folder/../../escape.txt", "", true},
+		{"absolute key stays under dest", "/tmp/dest", "/etc/passwd", "/tmp/dest/etc/passwd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := safeJoin(tt.dest, tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("safeJoin(%q, %q) = %q, want error", tt.dest, tt.key, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("safeJoin(%q, %q): %v", tt.dest, tt.key, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("safeJoin(%q, %q) = %q, want %q", tt.dest, tt.key, got, tt.want)
+			}
+		})
+	}
+}
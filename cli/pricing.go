@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// TierPricing holds the approximate retrieval costs for one tier in one
+// region. Rates are USD and intentionally coarse-grained: they're meant to
+// let operators compare tiers before committing, not to reproduce an AWS
+// invoice to the cent.
+type TierPricing struct {
+	PerGBRetrievalUSD      float64 `json:"perGBRetrievalUSD"`
+	PerThousandRequestsUSD float64 `json:"perThousandRequestsUSD"`
+}
+
+// defaultPricingTable is a built-in, approximate snapshot of Glacier Deep
+// Archive restore pricing, keyed by tier then region. Override or extend it
+// with -pricing-file for up-to-date or region-specific numbers.
+var defaultPricingTable = map[string]map[string]TierPricing{
+	"Bulk": {
+		"us-east-1": {PerGBRetrievalUSD: 0.0025, PerThousandRequestsUSD: 0.025},
+		"us-west-2": {PerGBRetrievalUSD: 0.0025, PerThousandRequestsUSD: 0.025},
+		"eu-west-1": {PerGBRetrievalUSD: 0.0027, PerThousandRequestsUSD: 0.026},
+		"*":         {PerGBRetrievalUSD: 0.0025, PerThousandRequestsUSD: 0.025},
+	},
+	"Standard": {
+		"us-east-1": {PerGBRetrievalUSD: 0.02, PerThousandRequestsUSD: 0.05},
+		"us-west-2": {PerGBRetrievalUSD: 0.02, PerThousandRequestsUSD: 0.05},
+		"eu-west-1": {PerGBRetrievalUSD: 0.022, PerThousandRequestsUSD: 0.055},
+		"*":         {PerGBRetrievalUSD: 0.02, PerThousandRequestsUSD: 0.05},
+	},
+	"Expedited": {
+		"us-east-1": {PerGBRetrievalUSD: 0.03, PerThousandRequestsUSD: 10},
+		"us-west-2": {PerGBRetrievalUSD: 0.03, PerThousandRequestsUSD: 10},
+		"eu-west-1": {PerGBRetrievalUSD: 0.033, PerThousandRequestsUSD: 11},
+		"*":         {PerGBRetrievalUSD: 0.03, PerThousandRequestsUSD: 10},
+	},
+}
+
+// loadPricingTable returns the built-in pricing table, merged with overrides
+// from path if one is given. Overrides are applied tier by region, so a file
+// only needs to specify the entries it wants to change.
+func loadPricingTable(path string) (map[string]map[string]TierPricing, error) {
+	if path == "" {
+		return defaultPricingTable, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading pricing file: %w", err)
+	}
+
+	var overrides map[string]map[string]TierPricing
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing pricing file: %w", err)
+	}
+
+	table := make(map[string]map[string]TierPricing, len(defaultPricingTable))
+	for tier, regions := range defaultPricingTable {
+		table[tier] = make(map[string]TierPricing, len(regions))
+		for region, pricing := range regions {
+			table[tier][region] = pricing
+		}
+	}
+
+	for tier, regions := range overrides {
+		if table[tier] == nil {
+			table[tier] = make(map[string]TierPricing, len(regions))
+		}
+
+		for region, pricing := range regions {
+			table[tier][region] = pricing
+		}
+	}
+
+	return table, nil
+}
+
+// lookupPricing finds the pricing for tier/region, falling back to the
+// tier's "*" wildcard entry when the specific region isn't listed.
+func lookupPricing(table map[string]map[string]TierPricing, tier types.Tier, region string) (TierPricing, bool) {
+	regions, ok := table[string(tier)]
+	if !ok {
+		return TierPricing{}, false
+	}
+
+	if pricing, ok := regions[region]; ok {
+		return pricing, true
+	}
+
+	pricing, ok := regions["*"]
+
+	return pricing, ok
+}
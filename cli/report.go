@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// storageClassStat aggregates object count and size for one storage class.
+type storageClassStat struct {
+	Count int64 `json:"count"`
+	Bytes int64 `json:"bytes"`
+}
+
+// DryRunReport accumulates what a -dry-run pass observed across all listed
+// objects so operators can compare restore tiers before committing to one.
+// It's safe to update concurrently from worker goroutines.
+type DryRunReport struct {
+	mu sync.Mutex
+
+	Tier   string `json:"tier"`
+	Region string `json:"region"`
+
+	ByStorageClass map[string]*storageClassStat `json:"byStorageClass"`
+
+	EligibleObjects int64 `json:"eligibleObjects"`
+	EligibleBytes   int64 `json:"eligibleBytes"`
+
+	EstimatedCostUSD float64 `json:"estimatedCostUSD"`
+	PricingAvailable bool    `json:"pricingAvailable"`
+}
+
+// NewDryRunReport creates an empty report for the given tier and region.
+func NewDryRunReport(tier types.Tier, region string) *DryRunReport {
+	return &DryRunReport{
+		Tier:           string(tier),
+		Region:         region,
+		ByStorageClass: make(map[string]*storageClassStat),
+	}
+}
+
+// ObserveSeen records every listed object, regardless of eligibility, so the
+// report shows the full storage-class composition of the scanned path.
+func (r *DryRunReport) ObserveSeen(storageClass types.ObjectStorageClass, size int64) {
+	class := string(storageClass)
+	if class == "" {
+		class = "STANDARD"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat, ok := r.ByStorageClass[class]
+	if !ok {
+		stat = &storageClassStat{}
+		r.ByStorageClass[class] = stat
+	}
+
+	stat.Count++
+	stat.Bytes += size
+}
+
+// ObserveEligible records an object that would actually be restored.
+func (r *DryRunReport) ObserveEligible(size int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.EligibleObjects++
+	r.EligibleBytes += size
+}
+
+// Finalize computes the estimated retrieval cost from the accumulated
+// eligible bytes/objects using the given pricing table.
+func (r *DryRunReport) Finalize(table map[string]map[string]TierPricing) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pricing, ok := lookupPricing(table, types.Tier(r.Tier), r.Region)
+	r.PricingAvailable = ok
+
+	if !ok {
+		return
+	}
+
+	gb := float64(r.EligibleBytes) / 1e9
+	thousandRequests := float64(r.EligibleObjects) / 1000
+
+	r.EstimatedCostUSD = gb*pricing.PerGBRetrievalUSD + thousandRequests*pricing.PerThousandRequestsUSD
+}
+
+// Print writes a human-readable summary to the logger and the full report as
+// JSON to stdout, for piping into other tools.
+func (r *DryRunReport) Print() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	classes := make([]string, 0, len(r.ByStorageClass))
+	for class := range r.ByStorageClass {
+		classes = append(classes, class)
+	}
+
+	sort.Strings(classes)
+
+	logger.Info("Dry-run summary:")
+	logger.Infof("  Tier: %s, region: %s", r.Tier, r.Region)
+
+	for _, class := range classes {
+		stat := r.ByStorageClass[class]
+		logger.Infof("  %s: %d objects, %s", class, stat.Count, humanBytes(stat.Bytes))
+	}
+
+	logger.Infof("  Eligible for restore: %d objects, %s", r.EligibleObjects, humanBytes(r.EligibleBytes))
+
+	if r.PricingAvailable {
+		logger.Infof("  Estimated retrieval cost: $%.2f", r.EstimatedCostUSD)
+	} else {
+		logger.Warn("  No pricing data for this tier/region; estimated cost omitted")
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		logger.Warnf("Failed to render JSON report: %v", err)
+
+		return
+	}
+
+	fmt.Println(string(data))
+}
+
+// humanBytes formats a byte count using binary (KiB/MiB/...) units.
+func humanBytes(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for num := n / unit; num >= unit; num /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.2f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}